@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// defaultCacheTTL is how long a successful fetch of all-events.json is
+// considered fresh when EVENTS_CACHE_TTL is not set.
+const defaultCacheTTL = 15 * time.Minute
+
+var (
+	cacheMu        sync.RWMutex
+	cachedEvents   []Event
+	cachedETag     string
+	cachedModified string
+	cachedAt       time.Time
+
+	// eventFetchGroup coalesces concurrent cache misses into a single
+	// upstream request.
+	eventFetchGroup singleflight.Group
+)
+
+// cacheTTL returns the configured freshness window for the event cache,
+// read from EVENTS_CACHE_TTL (a Go duration string, e.g. "5m") or
+// defaultCacheTTL if unset or invalid.
+func cacheTTL() time.Duration {
+	if v := os.Getenv("EVENTS_CACHE_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return defaultCacheTTL
+}
+
+// getCachedEvents returns the cached events if they are still within TTL.
+func getCachedEvents() ([]Event, bool) {
+	cacheMu.RLock()
+	defer cacheMu.RUnlock()
+	if cachedEvents == nil || time.Since(cachedAt) > cacheTTL() {
+		return nil, false
+	}
+	return cachedEvents, true
+}
+
+// cachedValidators returns the ETag/Last-Modified values of the last
+// successful fetch, for use in a conditional GET, even if the cache has
+// gone stale.
+func cachedValidators() (etag, lastModified string) {
+	cacheMu.RLock()
+	defer cacheMu.RUnlock()
+	return cachedETag, cachedModified
+}
+
+// storeEventCache records a freshly fetched and parsed event list along
+// with the validators needed for the next conditional GET.
+func storeEventCache(events []Event, etag, lastModified string) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cachedEvents = events
+	cachedETag = etag
+	cachedModified = lastModified
+	cachedAt = time.Now()
+}
+
+// touchEventCache resets the cache's freshness timer after a 304 Not
+// Modified response and returns the still-valid cached events.
+func touchEventCache() []Event {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cachedAt = time.Now()
+	return cachedEvents
+}
+
+// invalidateEventCache discards the current cache entry so the next call
+// to fetchAndParseEvents hits the upstream server.
+func invalidateEventCache() {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cachedAt = time.Time{}
+}
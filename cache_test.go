@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// resetEventCache clears the package-level cache so tests don't leak
+// state into each other.
+func resetEventCache() {
+	cacheMu.Lock()
+	cachedEvents = nil
+	cachedETag = ""
+	cachedModified = ""
+	cachedAt = time.Time{}
+	cacheMu.Unlock()
+}
+
+func sampleEventsPayload() []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"name":      "CacheConf 2025",
+			"hyperlink": "https://cacheconf.example.com",
+			"date":      []int64{1762752000000},
+			"location":  "Virtual",
+			"cfp":       map[string]interface{}{},
+			"status":    "open",
+		},
+	}
+}
+
+func TestFetchAndParseEventsCoalescesConcurrentCalls(t *testing.T) {
+	originalURL := eventDataURL
+	defer func() { eventDataURL = originalURL }()
+	resetEventCache()
+	defer resetEventCache()
+
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		json.NewEncoder(w).Encode(sampleEventsPayload())
+	}))
+	defer server.Close()
+	eventDataURL = server.URL
+
+	const n = 20
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := fetchAndParseEvents(context.Background()); err != nil {
+				t.Errorf("fetchAndParseEvents returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("expected exactly 1 upstream hit for %d concurrent calls, got %d", n, got)
+	}
+}
+
+func TestFetchAndParseEventsReusesCacheOn304(t *testing.T) {
+	originalURL := eventDataURL
+	defer func() { eventDataURL = originalURL }()
+	resetEventCache()
+	defer resetEventCache()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode(sampleEventsPayload())
+	}))
+	defer server.Close()
+	eventDataURL = server.URL
+
+	first, err := fetchAndParseEvents(context.Background())
+	if err != nil {
+		t.Fatalf("initial fetchAndParseEvents returned error: %v", err)
+	}
+
+	invalidateEventCache()
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		t.Errorf("expected conditional GET with If-None-Match, got %q", r.Header.Get("If-None-Match"))
+	})
+
+	second, err := fetchAndParseEvents(context.Background())
+	if err != nil {
+		t.Fatalf("conditional fetchAndParseEvents returned error: %v", err)
+	}
+
+	if len(second) != len(first) || second[0].Name != first[0].Name {
+		t.Errorf("expected 304 response to reuse cached events, got %+v", second)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// CalendarLinkSet holds pre-built "add to calendar" URLs for the major
+// web calendar providers.
+type CalendarLinkSet struct {
+	Google  string `json:"google"`
+	Outlook string `json:"outlook"`
+	Yahoo   string `json:"yahoo"`
+}
+
+// EventCalendarLinks pairs an event with its calendar links, plus a
+// second set of links for the CFP deadline when the event has one.
+type EventCalendarLinks struct {
+	Name        string           `json:"name"`
+	Conference  CalendarLinkSet  `json:"conference"`
+	CFPDeadline *CalendarLinkSet `json:"cfpDeadline,omitempty"`
+}
+
+// buildEventCalendarLinks renders the calendar links for a conference and,
+// when it has a CFP link and a known deadline, a second set of links for
+// that deadline. The deadline is not compared with the current time, so a
+// CFP that has already closed still gets its links.
+func buildEventCalendarLinks(event Event) EventCalendarLinks {
+	links := EventCalendarLinks{
+		Name:       event.Name,
+		Conference: buildConferenceLinkSet(event),
+	}
+
+	if event.CFP.Link != "" && !event.CFPEndDate.IsZero() {
+		cfp := buildCFPLinkSet(event)
+		links.CFPDeadline = &cfp
+	}
+
+	return links
+}
+
+// buildConferenceLinkSet builds the calendar links for the conference
+// itself, rendering an all-day or timed event depending on how many
+// timestamps the event has.
+func buildConferenceLinkSet(event Event) CalendarLinkSet {
+	allDay := len(event.DateTimestamps) <= 1
+
+	start, end := event.StartDate, event.EndDate
+	if allDay {
+		end = event.StartDate.AddDate(0, 0, 1)
+	}
+
+	return buildCalendarLinkSet(event.Name, event.URL, eventLocation(event), start, end, allDay)
+}
+
+// buildCFPLinkSet builds the calendar links for a CFP deadline, rendered
+// as a one-hour timed reminder ending at the deadline.
+func buildCFPLinkSet(event Event) CalendarLinkSet {
+	title := fmt.Sprintf("CFP deadline: %s", event.Name)
+	return buildCalendarLinkSet(title, event.CFP.Link, eventLocation(event), event.CFPEndDate.Add(-time.Hour), event.CFPEndDate, false)
+}
+
+// buildCalendarLinkSet renders the Google/Outlook/Yahoo "add to calendar"
+// URLs for a single title/details/location/time-range combination.
+func buildCalendarLinkSet(title, details, location string, start, end time.Time, allDay bool) CalendarLinkSet {
+	return CalendarLinkSet{
+		Google:  googleCalendarLink(title, details, location, start, end, allDay),
+		Outlook: outlookCalendarLink(title, details, location, start, end, allDay),
+		Yahoo:   yahooCalendarLink(title, details, location, start, end, allDay),
+	}
+}
+
+func googleCalendarLink(title, details, location string, start, end time.Time, allDay bool) string {
+	v := url.Values{}
+	v.Set("action", "TEMPLATE")
+	v.Set("text", title)
+	v.Set("dates", fmt.Sprintf("%s/%s", formatCalendarTime(start, allDay), formatCalendarTime(end, allDay)))
+	if details != "" {
+		v.Set("details", details)
+	}
+	if location != "" {
+		v.Set("location", location)
+	}
+	return "https://calendar.google.com/calendar/render?" + v.Encode()
+}
+
+func outlookCalendarLink(title, details, location string, start, end time.Time, allDay bool) string {
+	v := url.Values{}
+	v.Set("path", "/calendar/action/compose")
+	v.Set("rru", "addevent")
+	v.Set("subject", title)
+	v.Set("startdt", start.Format(time.RFC3339))
+	v.Set("enddt", end.Format(time.RFC3339))
+	if allDay {
+		v.Set("allday", "true")
+	}
+	if details != "" {
+		v.Set("body", details)
+	}
+	if location != "" {
+		v.Set("location", location)
+	}
+	return "https://outlook.live.com/calendar/0/deeplink/compose?" + v.Encode()
+}
+
+func yahooCalendarLink(title, details, location string, start, end time.Time, allDay bool) string {
+	v := url.Values{}
+	v.Set("v", "60")
+	v.Set("view", "d")
+	v.Set("type", "20")
+	v.Set("title", title)
+	v.Set("st", formatCalendarTime(start, allDay))
+	v.Set("et", formatCalendarTime(end, allDay))
+	if allDay {
+		v.Set("dur", "allday")
+	}
+	if details != "" {
+		v.Set("desc", details)
+	}
+	if location != "" {
+		v.Set("in_loc", location)
+	}
+	return "https://calendar.yahoo.com/?" + v.Encode()
+}
+
+// formatCalendarTime renders t the way Google/Yahoo expect it in their
+// calendar URLs: a bare date for all-day events, a UTC timestamp
+// otherwise.
+func formatCalendarTime(t time.Time, allDay bool) string {
+	if allDay {
+		return t.Format(icalDateLayout)
+	}
+	return t.UTC().Format(icalDateTimeLayout)
+}
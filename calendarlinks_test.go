@@ -0,0 +1,122 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestBuildConferenceLinkSetAllDay(t *testing.T) {
+	event := Event{
+		Name:           "Single Day Conf",
+		DateTimestamps: []int64{1762752000000},
+		URL:            "https://singleday.example.com",
+		City:           "Berlin",
+		Country:        "Germany",
+	}
+	event.StartDate = millisToTime(event.DateTimestamps[0])
+	event.EndDate = event.StartDate
+
+	links := buildConferenceLinkSet(event)
+
+	google, err := url.Parse(links.Google)
+	if err != nil {
+		t.Fatalf("invalid Google link: %v", err)
+	}
+	q := google.Query()
+	if q.Get("text") != event.Name {
+		t.Errorf("expected Google link text to be %q, got %q", event.Name, q.Get("text"))
+	}
+	wantDates := event.StartDate.Format(icalDateLayout) + "/" + event.StartDate.AddDate(0, 0, 1).Format(icalDateLayout)
+	if q.Get("dates") != wantDates {
+		t.Errorf("expected Google link dates %q, got %q", wantDates, q.Get("dates"))
+	}
+
+	outlook, err := url.Parse(links.Outlook)
+	if err != nil {
+		t.Fatalf("invalid Outlook link: %v", err)
+	}
+	if outlook.Query().Get("allday") != "true" {
+		t.Errorf("expected Outlook link to be marked allday=true, got %q", outlook.Query().Get("allday"))
+	}
+
+	yahoo, err := url.Parse(links.Yahoo)
+	if err != nil {
+		t.Fatalf("invalid Yahoo link: %v", err)
+	}
+	if yahoo.Query().Get("dur") != "allday" {
+		t.Errorf("expected Yahoo link dur=allday, got %q", yahoo.Query().Get("dur"))
+	}
+}
+
+func TestBuildConferenceLinkSetTimed(t *testing.T) {
+	event := Event{
+		Name:           "Multi Day Conf",
+		DateTimestamps: []int64{1764048000000, 1764220800000},
+		Location:       "Convention Center",
+		City:           "Madrid",
+		Country:        "Spain",
+	}
+	event.StartDate = millisToTime(event.DateTimestamps[0])
+	event.EndDate = millisToTime(event.DateTimestamps[1])
+
+	links := buildConferenceLinkSet(event)
+
+	google, err := url.Parse(links.Google)
+	if err != nil {
+		t.Fatalf("invalid Google link: %v", err)
+	}
+	wantDates := event.StartDate.Format(icalDateTimeLayout) + "/" + event.EndDate.Format(icalDateTimeLayout)
+	if google.Query().Get("dates") != wantDates {
+		t.Errorf("expected Google link dates %q, got %q", wantDates, google.Query().Get("dates"))
+	}
+	if !strings.Contains(google.Query().Get("location"), "Madrid") {
+		t.Errorf("expected Google link location to include city, got %q", google.Query().Get("location"))
+	}
+
+	outlook, err := url.Parse(links.Outlook)
+	if err != nil {
+		t.Fatalf("invalid Outlook link: %v", err)
+	}
+	if outlook.Query().Get("allday") != "" {
+		t.Errorf("expected timed event to omit allday param, got %q", outlook.Query().Get("allday"))
+	}
+}
+
+func TestBuildEventCalendarLinksIncludesCFPDeadline(t *testing.T) {
+	event := Event{
+		Name:           "CFP Conf",
+		DateTimestamps: []int64{1764048000000},
+		CFP: CFPInfo{
+			Link: "https://cfpconf.example.com/cfp",
+		},
+	}
+	event.StartDate = millisToTime(event.DateTimestamps[0])
+	event.EndDate = event.StartDate
+	event.CFPEndDate = millisToTime(1754323200000)
+
+	links := buildEventCalendarLinks(event)
+
+	if links.CFPDeadline == nil {
+		t.Fatal("expected a CFP deadline link set to be present")
+	}
+
+	google, err := url.Parse(links.CFPDeadline.Google)
+	if err != nil {
+		t.Fatalf("invalid CFP Google link: %v", err)
+	}
+	if !strings.Contains(google.Query().Get("text"), "CFP deadline") {
+		t.Errorf("expected CFP link title to mention the deadline, got %q", google.Query().Get("text"))
+	}
+}
+
+func TestBuildEventCalendarLinksOmitsCFPDeadlineWhenAbsent(t *testing.T) {
+	event := Event{Name: "No CFP Conf", DateTimestamps: []int64{1764048000000}}
+	event.StartDate = millisToTime(event.DateTimestamps[0])
+	event.EndDate = event.StartDate
+
+	links := buildEventCalendarLinks(event)
+	if links.CFPDeadline != nil {
+		t.Errorf("expected no CFP deadline link set when CFP.Link is empty, got %+v", links.CFPDeadline)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultHTTPTimeout = 10 * time.Second
+	defaultHTTPRetries = 3
+)
+
+// retryBackoffs are the base delays used before each retry attempt (i.e.
+// retryBackoffs[0] is the wait before the 2nd overall attempt). The last
+// entry is reused for any additional retries beyond len(retryBackoffs).
+var retryBackoffs = []time.Duration{250 * time.Millisecond, 1 * time.Second, 4 * time.Second}
+
+// httpClient is the package-level client used for all upstream requests.
+// Its Timeout is configured from EVENTS_HTTP_TIMEOUT by configureHTTPClient.
+var httpClient = &http.Client{Timeout: defaultHTTPTimeout}
+
+// httpRetries is how many retry attempts doWithRetry makes after the
+// initial request, configured from EVENTS_HTTP_RETRIES.
+var httpRetries = defaultHTTPRetries
+
+// configureHTTPClient applies EVENTS_HTTP_TIMEOUT and EVENTS_HTTP_RETRIES
+// from the environment, falling back to the defaults above. Called once
+// from main; tests that need different values can set httpClient.Timeout
+// and httpRetries directly.
+func configureHTTPClient() {
+	if v := os.Getenv("EVENTS_HTTP_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			httpClient.Timeout = d
+		}
+	}
+	if v := os.Getenv("EVENTS_HTTP_RETRIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			httpRetries = n
+		}
+	}
+}
+
+// doWithRetry executes req, retrying on network errors and 5xx responses
+// with exponential backoff and jitter, honoring a Retry-After header when
+// the upstream sends one. It gives up after httpRetries retries (or
+// sooner if ctx is canceled) and returns the last response/error.
+func doWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
+	var lastErr error
+
+	for attempt := 0; ; attempt++ {
+		resp, err := httpClient.Do(req.Clone(ctx))
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			return resp, nil
+		}
+
+		if err != nil {
+			lastErr = err
+		} else {
+			lastErr = nil
+		}
+
+		if attempt >= httpRetries {
+			if resp != nil {
+				return resp, nil
+			}
+			return nil, lastErr
+		}
+
+		wait := backoffDelay(attempt)
+		if resp != nil {
+			if ra, ok := retryAfterDelay(resp.Header.Get("Retry-After")); ok {
+				wait = ra
+			}
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// backoffDelay returns the jittered delay to wait before retry attempt n
+// (0-indexed), picked from retryBackoffs and randomized to within
+// [50%, 100%] of the base to avoid synchronized retries.
+func backoffDelay(attempt int) time.Duration {
+	if attempt >= len(retryBackoffs) {
+		attempt = len(retryBackoffs) - 1
+	}
+	base := retryBackoffs[attempt]
+	return base/2 + time.Duration(rand.Int63n(int64(base)/2+1))
+}
+
+// retryAfterDelay parses a Retry-After header value, which may be a
+// number of seconds or an HTTP-date.
+func retryAfterDelay(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoWithRetrySucceedsAfterTransientFailures(t *testing.T) {
+	originalRetries, originalBackoffs := httpRetries, retryBackoffs
+	httpRetries = 3
+	retryBackoffs = []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond}
+	defer func() {
+		httpRetries = originalRetries
+		retryBackoffs = originalBackoffs
+	}()
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+
+	resp, err := doWithRetry(context.Background(), req)
+	if err != nil {
+		t.Fatalf("doWithRetry returned error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected final status 200, got %d", resp.StatusCode)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestDoWithRetryGivesUpAfterExhaustingRetries(t *testing.T) {
+	originalRetries, originalBackoffs := httpRetries, retryBackoffs
+	httpRetries = 2
+	retryBackoffs = []time.Duration{time.Millisecond, time.Millisecond}
+	defer func() {
+		httpRetries = originalRetries
+		retryBackoffs = originalBackoffs
+	}()
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+
+	resp, err := doWithRetry(context.Background(), req)
+	if err != nil {
+		t.Fatalf("doWithRetry returned error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected final status 500, got %d", resp.StatusCode)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("expected 1 initial attempt + 2 retries = 3, got %d", got)
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	testCases := []struct {
+		header  string
+		wantOK  bool
+		wantMin time.Duration
+	}{
+		{"", false, 0},
+		{"5", true, 5 * time.Second},
+		{"not-a-date", false, 0},
+	}
+
+	for _, tc := range testCases {
+		delay, ok := retryAfterDelay(tc.header)
+		if ok != tc.wantOK {
+			t.Errorf("retryAfterDelay(%q) ok = %v, want %v", tc.header, ok, tc.wantOK)
+			continue
+		}
+		if ok && delay != tc.wantMin {
+			t.Errorf("retryAfterDelay(%q) = %v, want %v", tc.header, delay, tc.wantMin)
+		}
+	}
+}
+
+func TestBackoffDelayWithinBounds(t *testing.T) {
+	for attempt, base := range retryBackoffs {
+		delay := backoffDelay(attempt)
+		if delay < base/2 || delay > base {
+			t.Errorf("backoffDelay(%d) = %v, want within [%v, %v]", attempt, delay, base/2, base)
+		}
+	}
+}
+
+func TestBackoffDelayReusesLastBackoffBeyondBounds(t *testing.T) {
+	last := retryBackoffs[len(retryBackoffs)-1]
+
+	for _, attempt := range []int{len(retryBackoffs), len(retryBackoffs) + 5} {
+		delay := backoffDelay(attempt)
+		if delay < last/2 || delay > last {
+			t.Errorf("backoffDelay(%d) = %v, want within [%v, %v]", attempt, delay, last/2, last)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const icalDateTimeLayout = "20060102T150405Z"
+const icalDateLayout = "20060102"
+
+// icalUID derives a stable identifier for a VEVENT/VTODO from the fields
+// that make an event unique, so re-exporting the same feed produces the
+// same UID and calendar clients can dedupe/update rather than duplicate.
+func icalUID(parts ...string) string {
+	sum := sha1.Sum([]byte(strings.Join(parts, "|")))
+	return fmt.Sprintf("%x@developers.events", sum)
+}
+
+// icalEscape escapes the characters RFC 5545 reserves in TEXT values.
+func icalEscape(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`;`, `\;`,
+		`,`, `\,`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(s)
+}
+
+// eventLocation builds the iCal LOCATION value from whichever of
+// Location/City/Country the event has set.
+func eventLocation(event Event) string {
+	parts := make([]string, 0, 3)
+	for _, p := range []string{event.Location, event.City, event.Country} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// icalDateRange renders DTSTART/DTEND lines for an event. A single
+// timestamp is treated as an all-day event (VALUE=DATE, DTEND exclusive
+// per RFC 5545 so it covers the one day); more than one timestamp is
+// rendered as a timed UTC range.
+func icalDateRange(event Event) []string {
+	if len(event.DateTimestamps) <= 1 {
+		start := event.StartDate
+		return []string{
+			fmt.Sprintf("DTSTART;VALUE=DATE:%s", start.Format(icalDateLayout)),
+			fmt.Sprintf("DTEND;VALUE=DATE:%s", start.AddDate(0, 0, 1).Format(icalDateLayout)),
+		}
+	}
+	return []string{
+		fmt.Sprintf("DTSTART:%s", event.StartDate.Format(icalDateTimeLayout)),
+		fmt.Sprintf("DTEND:%s", event.EndDate.Format(icalDateTimeLayout)),
+	}
+}
+
+// buildEventVEVENT renders the VEVENT block for a conference.
+func buildEventVEVENT(event Event, now time.Time) string {
+	var b strings.Builder
+	b.WriteString("BEGIN:VEVENT\r\n")
+	fmt.Fprintf(&b, "UID:%s\r\n", icalUID(event.Name, event.StartDate.String(), event.URL))
+	fmt.Fprintf(&b, "DTSTAMP:%s\r\n", now.UTC().Format(icalDateTimeLayout))
+	for _, line := range icalDateRange(event) {
+		fmt.Fprintf(&b, "%s\r\n", line)
+	}
+	fmt.Fprintf(&b, "SUMMARY:%s\r\n", icalEscape(event.Name))
+	if loc := eventLocation(event); loc != "" {
+		fmt.Fprintf(&b, "LOCATION:%s\r\n", icalEscape(loc))
+	}
+	if event.URL != "" {
+		fmt.Fprintf(&b, "URL:%s\r\n", event.URL)
+	}
+	b.WriteString("END:VEVENT\r\n")
+	return b.String()
+}
+
+// buildCFPVEVENT renders a VEVENT for the CFP deadline, with a VALARM
+// firing 7 days before so the reminder survives in calendar apps that
+// don't support standalone VTODOs well.
+func buildCFPVEVENT(event Event, now time.Time) string {
+	var b strings.Builder
+	b.WriteString("BEGIN:VEVENT\r\n")
+	fmt.Fprintf(&b, "UID:%s\r\n", icalUID(event.Name, event.CFPEndDate.String(), event.CFP.Link, "cfp"))
+	fmt.Fprintf(&b, "DTSTAMP:%s\r\n", now.UTC().Format(icalDateTimeLayout))
+	fmt.Fprintf(&b, "DTSTART:%s\r\n", event.CFPEndDate.Format(icalDateTimeLayout))
+	fmt.Fprintf(&b, "DTEND:%s\r\n", event.CFPEndDate.Format(icalDateTimeLayout))
+	fmt.Fprintf(&b, "SUMMARY:%s\r\n", icalEscape(fmt.Sprintf("CFP deadline: %s", event.Name)))
+	fmt.Fprintf(&b, "URL:%s\r\n", event.CFP.Link)
+	b.WriteString("BEGIN:VALARM\r\n")
+	b.WriteString("ACTION:DISPLAY\r\n")
+	fmt.Fprintf(&b, "DESCRIPTION:%s\r\n", icalEscape(fmt.Sprintf("CFP for %s closes soon", event.Name)))
+	b.WriteString("TRIGGER:-P7D\r\n")
+	b.WriteString("END:VALARM\r\n")
+	b.WriteString("END:VEVENT\r\n")
+	return b.String()
+}
+
+// buildICalendar renders a set of events as an RFC 5545 VCALENDAR feed,
+// including a CFP deadline VEVENT (with a 7-day-before VALARM reminder)
+// for every event that has a CFP link and a CFP end date, whether or not
+// that deadline has already passed.
+func buildICalendar(events []Event) string {
+	now := time.Now()
+
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\n")
+	b.WriteString("VERSION:2.0\r\n")
+	b.WriteString("PRODID:-//developers-conferences-agenda-mcp//EN\r\n")
+	b.WriteString("CALSCALE:GREGORIAN\r\n")
+
+	for _, event := range events {
+		b.WriteString(buildEventVEVENT(event, now))
+		if event.CFP.Link != "" && !event.CFPEndDate.IsZero() {
+			b.WriteString(buildCFPVEVENT(event, now))
+		}
+	}
+
+	b.WriteString("END:VCALENDAR\r\n")
+	return b.String()
+}
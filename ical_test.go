@@ -0,0 +1,131 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIcalEscape(t *testing.T) {
+	testCases := []struct {
+		in       string
+		expected string
+	}{
+		{"Plain", "Plain"},
+		{"Foo, Bar", `Foo\, Bar`},
+		{"A; B", `A\; B`},
+		{"back\\slash", `back\\slash`},
+		{"line\nbreak", `line\nbreak`},
+	}
+
+	for _, tc := range testCases {
+		result := icalEscape(tc.in)
+		if result != tc.expected {
+			t.Errorf("icalEscape(%q) = %q, expected %q", tc.in, result, tc.expected)
+		}
+	}
+}
+
+func TestIcalUIDStable(t *testing.T) {
+	a := icalUID("KubeCon", "2025-11-10", "https://kubecon.example.com")
+	b := icalUID("KubeCon", "2025-11-10", "https://kubecon.example.com")
+	if a != b {
+		t.Errorf("expected icalUID to be stable for identical inputs, got %q and %q", a, b)
+	}
+
+	c := icalUID("KubeCon", "2025-11-11", "https://kubecon.example.com")
+	if a == c {
+		t.Errorf("expected icalUID to differ when inputs differ, both were %q", a)
+	}
+
+	if !strings.HasSuffix(a, "@developers.events") {
+		t.Errorf("expected icalUID to be namespaced with @developers.events, got %q", a)
+	}
+}
+
+func TestBuildICalendar(t *testing.T) {
+	allDay := Event{
+		Name:           "Single Day Conf",
+		DateTimestamps: []int64{1762752000000},
+		URL:            "https://singleday.example.com",
+		City:           "Berlin",
+		Country:        "Germany",
+	}
+	allDay.StartDate = millisToTime(allDay.DateTimestamps[0])
+	allDay.EndDate = allDay.StartDate
+
+	timed := Event{
+		Name:           "Multi Day Conf",
+		DateTimestamps: []int64{1764048000000, 1764220800000},
+		URL:            "https://multiday.example.com",
+		Location:       "Convention Center",
+		City:           "Madrid",
+		Country:        "Spain",
+		CFP: CFPInfo{
+			Link: "https://multiday.example.com/cfp",
+		},
+	}
+	timed.StartDate = millisToTime(timed.DateTimestamps[0])
+	timed.EndDate = millisToTime(timed.DateTimestamps[1])
+	timed.CFPEndDate = millisToTime(1754323200000)
+
+	ics := buildICalendar([]Event{allDay, timed})
+
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n") {
+		t.Errorf("expected feed to start with BEGIN:VCALENDAR, got %q", ics[:40])
+	}
+	if !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Errorf("expected feed to end with END:VCALENDAR")
+	}
+	if strings.Count(ics, "BEGIN:VEVENT") != 3 {
+		t.Errorf("expected 3 VEVENTs (2 conferences + 1 CFP deadline), got %d", strings.Count(ics, "BEGIN:VEVENT"))
+	}
+	if !strings.Contains(ics, "DTSTART;VALUE=DATE:20251110") {
+		t.Errorf("expected all-day event to use DATE-valued DTSTART, got:\n%s", ics)
+	}
+	if !strings.Contains(ics, "DTSTART:20251125T052000Z") {
+		t.Errorf("expected timed event to use UTC DTSTART, got:\n%s", ics)
+	}
+	if !strings.Contains(ics, "LOCATION:Convention Center\\, Madrid\\, Spain") {
+		t.Errorf("expected LOCATION to combine location/city/country, got:\n%s", ics)
+	}
+	if !strings.Contains(ics, "SUMMARY:CFP deadline: Multi Day Conf") {
+		t.Errorf("expected a CFP deadline VEVENT, got:\n%s", ics)
+	}
+	if !strings.Contains(ics, "TRIGGER:-P7D") {
+		t.Errorf("expected CFP deadline VEVENT to have a 7-day VALARM, got:\n%s", ics)
+	}
+}
+
+func TestIcalDateRangeSingleDay(t *testing.T) {
+	event := Event{DateTimestamps: []int64{1762752000000}}
+	event.StartDate = millisToTime(event.DateTimestamps[0])
+
+	lines := icalDateRange(event)
+	want := []string{
+		"DTSTART;VALUE=DATE:" + event.StartDate.Format(icalDateLayout),
+		"DTEND;VALUE=DATE:" + event.StartDate.AddDate(0, 0, 1).Format(icalDateLayout),
+	}
+	for i, line := range lines {
+		if line != want[i] {
+			t.Errorf("icalDateRange()[%d] = %q, want %q", i, line, want[i])
+		}
+	}
+}
+
+func TestEventLocation(t *testing.T) {
+	testCases := []struct {
+		event    Event
+		expected string
+	}{
+		{Event{Location: "Hall A", City: "Berlin", Country: "Germany"}, "Hall A, Berlin, Germany"},
+		{Event{City: "Berlin", Country: "Germany"}, "Berlin, Germany"},
+		{Event{}, ""},
+	}
+
+	for _, tc := range testCases {
+		result := eventLocation(tc.event)
+		if result != tc.expected {
+			t.Errorf("eventLocation(%+v) = %q, expected %q", tc.event, result, tc.expected)
+		}
+	}
+}
@@ -1,15 +1,18 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	mcp_golang "github.com/metoro-io/mcp-golang"
-	"github.com/metoro-io/mcp-golang/transport/stdio"
 )
 
 var eventDataURL = "https://developers.events/all-events.json"
@@ -48,14 +51,15 @@ type EventData struct {
 
 // SearchEventsArgs defines parameters for searching events
 type SearchEventsArgs struct {
-	Query       string `json:"query" jsonschema:"description=Search query for event name or description"`
-	Location    string `json:"location" jsonschema:"description=Filter events by location"`
-	FromDate    string `json:"fromDate" jsonschema:"description=Filter events starting from this date (YYYY-MM-DD)"`
-	ToDate      string `json:"toDate" jsonschema:"description=Filter events up to this date (YYYY-MM-DD)"`
-	HasOpenCFP  bool   `json:"hasOpenCFP" jsonschema:"description=Only show events with open CFPs (Call for Papers)"`
-	CFPFromDate string `json:"cfpFromDate" jsonschema:"description=Filter events with CFP ending after this date (YYYY-MM-DD)"`
-	CFPToDate   string `json:"cfpToDate" jsonschema:"description=Filter events with CFP ending before this date (YYYY-MM-DD)"`
-	Limit       int    `json:"limit" jsonschema:"description=Maximum number of events to return"`
+	Query       string  `json:"query" jsonschema:"description=Search query for event name or description"`
+	Location    string  `json:"location" jsonschema:"description=Filter events by location"`
+	FromDate    string  `json:"fromDate" jsonschema:"description=Filter events starting from this date (YYYY-MM-DD)"`
+	ToDate      string  `json:"toDate" jsonschema:"description=Filter events up to this date (YYYY-MM-DD)"`
+	HasOpenCFP  bool    `json:"hasOpenCFP" jsonschema:"description=Only show events with open CFPs (Call for Papers)"`
+	CFPFromDate string  `json:"cfpFromDate" jsonschema:"description=Filter events with CFP ending after this date (YYYY-MM-DD)"`
+	CFPToDate   string  `json:"cfpToDate" jsonschema:"description=Filter events with CFP ending before this date (YYYY-MM-DD)"`
+	MinScore    float64 `json:"minScore" jsonschema:"description=Minimum relevance score (0-1) a result must have against query to be included in search_events results"`
+	Limit       int     `json:"limit" jsonschema:"description=Maximum number of events to return"`
 }
 
 type LimitArgs struct {
@@ -68,8 +72,8 @@ type DaysArgs struct {
 
 // FetchAndParseEvents retrieves the event data from the URL
 // Exported for testing
-func FetchAndParseEvents() ([]Event, error) {
-	return fetchAndParseEvents()
+func FetchAndParseEvents(ctx context.Context) ([]Event, error) {
+	return fetchAndParseEvents(ctx)
 }
 
 // fetchAndParseEvents retrieves the event data from the URL
@@ -81,13 +85,55 @@ func millisToTime(millis int64) time.Time {
 	return time.Unix(0, millis*int64(time.Millisecond)).UTC()
 }
 
-func fetchAndParseEvents() ([]Event, error) {
-	resp, err := http.Get(eventDataURL)
+func fetchAndParseEvents(ctx context.Context) ([]Event, error) {
+	if events, ok := getCachedEvents(); ok {
+		return events, nil
+	}
+
+	v, err, _ := eventFetchGroup.Do(eventDataURL, func() (interface{}, error) {
+		return fetchAndCacheEvents(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]Event), nil
+}
+
+// fetchAndCacheEvents performs the actual upstream request, sending
+// conditional GET headers from the current cache entry (if any) and
+// storing the result back in the cache. Callers should go through
+// fetchAndParseEvents, which coalesces concurrent callers via
+// eventFetchGroup.
+func fetchAndCacheEvents(ctx context.Context) ([]Event, error) {
+	// Another caller may have refreshed the cache while we were waiting
+	// for the singleflight lock.
+	if events, ok := getCachedEvents(); ok {
+		return events, nil
+	}
+
+	etag, lastModified := cachedValidators()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, eventDataURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := doWithRetry(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch events: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified {
+		return touchEventCache(), nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
@@ -97,6 +143,19 @@ func fetchAndParseEvents() ([]Event, error) {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	events, err := parseEvents(body)
+	if err != nil {
+		return nil, err
+	}
+
+	storeEventCache(events, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"))
+	return events, nil
+}
+
+// parseEvents unmarshals the raw all-events.json payload, handling both
+// the bare array and the `{"events": [...]}` wrapper, and fills in the
+// computed date fields.
+func parseEvents(body []byte) ([]Event, error) {
 	var events []Event
 	if err := json.Unmarshal(body, &events); err != nil {
 		// If direct unmarshal fails, try with wrapper structure
@@ -112,7 +171,7 @@ func fetchAndParseEvents() ([]Event, error) {
 		// Set start and end dates from the date array
 		if len(events[i].DateTimestamps) > 0 {
 			events[i].StartDate = millisToTime(events[i].DateTimestamps[0])
-			
+
 			// If there's more than one date, use the last one as end date
 			if len(events[i].DateTimestamps) > 1 {
 				events[i].EndDate = millisToTime(events[i].DateTimestamps[len(events[i].DateTimestamps)-1])
@@ -121,7 +180,7 @@ func fetchAndParseEvents() ([]Event, error) {
 				events[i].EndDate = events[i].StartDate
 			}
 		}
-		
+
 		// Process CFP end date
 		if events[i].CFP.UntilDate > 0 {
 			events[i].CFPEndDate = millisToTime(events[i].CFP.UntilDate)
@@ -132,84 +191,52 @@ func fetchAndParseEvents() ([]Event, error) {
 }
 
 func main() {
-	done := make(chan struct{})
+	cfg, err := parseTransportConfig(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 
-	server := mcp_golang.NewServer(stdio.NewStdioServerTransport())
+	if url := os.Getenv("EVENTS_URL"); url != "" {
+		eventDataURL = url
+	}
+	configureHTTPClient()
+
+	server, shutdown := newTransportServer(cfg)
+	if err := registerTools(server); err != nil {
+		panic(err)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- server.Serve() }()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := waitForShutdown(ctx, cfg, shutdown, serveErr); err != nil {
+		panic(err)
+	}
+}
 
+// registerTools wires every tool and resource this server exposes onto
+// server, regardless of which transport it is bound to.
+func registerTools(server *mcp_golang.Server) error {
 	// Register tool to search for events
-	err := server.RegisterTool("search_events", "Search for developer conferences and events", func(args SearchEventsArgs) (*mcp_golang.ToolResponse, error) {
-		events, err := fetchAndParseEvents()
+	err := server.RegisterTool("search_events", "Search for developer conferences and events", func(ctx context.Context, args SearchEventsArgs) (*mcp_golang.ToolResponse, error) {
+		events, err := fetchAndParseEvents(ctx)
 		if err != nil {
 			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error fetching events: %s", err))), nil
 		}
 
-		now := time.Now()
-
-		// Parse date filters if provided
-		var fromDate, toDate, cfpFromDate, cfpToDate time.Time
-		if args.FromDate != "" {
-			fromDate, err = time.Parse("2006-01-02", args.FromDate)
-			if err != nil {
-				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Invalid fromDate format: %s", err))), nil
-			}
-		}
-		if args.ToDate != "" {
-			toDate, err = time.Parse("2006-01-02", args.ToDate)
-			if err != nil {
-				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Invalid toDate format: %s", err))), nil
-			}
-		}
-		if args.CFPFromDate != "" {
-			cfpFromDate, err = time.Parse("2006-01-02", args.CFPFromDate)
-			if err != nil {
-				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Invalid cfpFromDate format: %s", err))), nil
-			}
-		}
-		if args.CFPToDate != "" {
-			cfpToDate, err = time.Parse("2006-01-02", args.CFPToDate)
-			if err != nil {
-				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Invalid cfpToDate format: %s", err))), nil
-			}
+		filteredEvents, err := filterByCommonArgs(events, args)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error filtering events: %s", err))), nil
 		}
 
-		// Filter and search events
-		var filteredEvents []Event
-		for _, event := range events {
-			// Apply filters
-			if args.Query != "" && !contains(event.Name+event.Location+event.City+event.Country+event.Misc, args.Query) {
-				continue
-			}
-			if args.Location != "" && !contains(event.Location, args.Location) {
-				continue
-			}
-			if !fromDate.IsZero() && event.StartDate.Before(fromDate) {
-				continue
-			}
-			if !toDate.IsZero() && event.StartDate.After(toDate) {
-				continue
-			}
-
-			// CFP filters
-			if args.HasOpenCFP && (!event.CFPEndDate.After(now) || event.CFP.Link == "") {
-				continue
-			}
-			if !cfpFromDate.IsZero() && event.CFPEndDate.Before(cfpFromDate) {
-				continue
-			}
-			if !cfpToDate.IsZero() && event.CFPEndDate.After(cfpToDate) {
-				continue
-			}
-
-			filteredEvents = append(filteredEvents, event)
-
-			// Respect limit if set
-			if args.Limit > 0 && len(filteredEvents) >= args.Limit {
-				break
-			}
-		}
+		scoredEvents := scoreAndRankEvents(filteredEvents, args)
 
 		// Convert to JSON for response
-		eventJSON, err := json.MarshalIndent(filteredEvents, "", "  ")
+		eventJSON, err := json.MarshalIndent(scoredEvents, "", "  ")
 		if err != nil {
 			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error encoding events: %s", err))), nil
 		}
@@ -217,12 +244,12 @@ func main() {
 		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(string(eventJSON))), nil
 	})
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	// Register tool for events with open CFPs
-	err = server.RegisterTool("open_cfps", "Get events with open CFP (Call for Papers)", func(args LimitArgs) (*mcp_golang.ToolResponse, error) {
-		events, err := fetchAndParseEvents()
+	err = server.RegisterTool("open_cfps", "Get events with open CFP (Call for Papers)", func(ctx context.Context, args LimitArgs) (*mcp_golang.ToolResponse, error) {
+		events, err := fetchAndParseEvents(ctx)
 		if err != nil {
 			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error fetching events: %s", err))), nil
 		}
@@ -249,12 +276,12 @@ func main() {
 		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(string(eventJSON))), nil
 	})
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	// Register resource for accessing all events
-	err = server.RegisterResource("events://all", "all_events", "All developer conferences and events", "application/json", func() (*mcp_golang.ResourceResponse, error) {
-		events, err := fetchAndParseEvents()
+	err = server.RegisterResource("events://all", "all_events", "All developer conferences and events", "application/json", func(ctx context.Context) (*mcp_golang.ResourceResponse, error) {
+		events, err := fetchAndParseEvents(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -267,12 +294,12 @@ func main() {
 		return mcp_golang.NewResourceResponse(mcp_golang.NewTextEmbeddedResource("events://all", string(eventJSON), "application/json")), nil
 	})
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	// Register resource for accessing events with open CFPs
-	err = server.RegisterResource("events://open-cfps", "open_cfps", "Events with open Call for Papers", "application/json", func() (*mcp_golang.ResourceResponse, error) {
-		events, err := fetchAndParseEvents()
+	err = server.RegisterResource("events://open-cfps", "open_cfps", "Events with open Call for Papers", "application/json", func(ctx context.Context) (*mcp_golang.ResourceResponse, error) {
+		events, err := fetchAndParseEvents(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -294,12 +321,12 @@ func main() {
 		return mcp_golang.NewResourceResponse(mcp_golang.NewTextEmbeddedResource("events://open-cfps", string(eventJSON), "application/json")), nil
 	})
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	// Register tool to get upcoming events
-	err = server.RegisterTool("upcoming_events", "Get upcoming developer conferences and events", func(args LimitArgs) (*mcp_golang.ToolResponse, error) {
-		events, err := fetchAndParseEvents()
+	err = server.RegisterTool("upcoming_events", "Get upcoming developer conferences and events", func(ctx context.Context, args LimitArgs) (*mcp_golang.ToolResponse, error) {
+		events, err := fetchAndParseEvents(ctx)
 		if err != nil {
 			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error fetching events: %s", err))), nil
 		}
@@ -325,16 +352,16 @@ func main() {
 		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(string(eventJSON))), nil
 	})
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	// Register tool to get CFP deadlines soon
-	err = server.RegisterTool("cfp_deadlines_soon", "Get events with CFP deadlines approaching within days", func(args DaysArgs) (*mcp_golang.ToolResponse, error) {
+	err = server.RegisterTool("cfp_deadlines_soon", "Get events with CFP deadlines approaching within days", func(ctx context.Context, args DaysArgs) (*mcp_golang.ToolResponse, error) {
 		if args.Days <= 0 {
 			args.Days = 30 // Default to 30 days if not specified
 		}
 
-		events, err := fetchAndParseEvents()
+		events, err := fetchAndParseEvents(ctx)
 		if err != nil {
 			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error fetching events: %s", err))), nil
 		}
@@ -358,15 +385,84 @@ func main() {
 		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(string(eventJSON))), nil
 	})
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	err = server.Serve()
+	// Register tool to export events as an iCalendar feed
+	err = server.RegisterTool("export_ical", "Export filtered developer conferences and CFP deadlines as an iCalendar (.ics) feed", func(ctx context.Context, args SearchEventsArgs) (*mcp_golang.ToolResponse, error) {
+		events, err := fetchAndParseEvents(ctx)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error fetching events: %s", err))), nil
+		}
+
+		filteredEvents, err := filterEvents(events, args)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error filtering events: %s", err))), nil
+		}
+
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(buildICalendar(filteredEvents))), nil
+	})
 	if err != nil {
-		panic(err)
+		return err
+	}
+
+	// Register resource for accessing all events as an iCalendar feed
+	err = server.RegisterResource("events://ical", "events_ical", "All developer conferences and CFP deadlines as an iCalendar feed", "text/calendar", func(ctx context.Context) (*mcp_golang.ResourceResponse, error) {
+		events, err := fetchAndParseEvents(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return mcp_golang.NewResourceResponse(mcp_golang.NewTextEmbeddedResource("events://ical", buildICalendar(events), "text/calendar")), nil
+	})
+	if err != nil {
+		return err
 	}
 
-	<-done
+	// Register tool to generate "add to calendar" links for events
+	err = server.RegisterTool("calendar_links", "Get pre-built Google/Outlook/Yahoo \"add to calendar\" links for filtered developer conferences and their CFP deadlines", func(ctx context.Context, args SearchEventsArgs) (*mcp_golang.ToolResponse, error) {
+		events, err := fetchAndParseEvents(ctx)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error fetching events: %s", err))), nil
+		}
+
+		filteredEvents, err := filterEvents(events, args)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error filtering events: %s", err))), nil
+		}
+
+		links := make([]EventCalendarLinks, 0, len(filteredEvents))
+		for _, event := range filteredEvents {
+			links = append(links, buildEventCalendarLinks(event))
+		}
+
+		linksJSON, err := json.MarshalIndent(links, "", "  ")
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error encoding calendar links: %s", err))), nil
+		}
+
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(string(linksJSON))), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Register tool to force a refresh of the cached event data
+	err = server.RegisterTool("refresh_events", "Invalidate the cached event data and re-fetch it from the upstream feed", func(ctx context.Context, args struct{}) (*mcp_golang.ToolResponse, error) {
+		invalidateEventCache()
+
+		events, err := fetchAndParseEvents(ctx)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error refreshing events: %s", err))), nil
+		}
+
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Refreshed %d events", len(events)))), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 // Helper function to check if a string contains a substring (case-insensitive)
@@ -374,3 +470,90 @@ func contains(s, substr string) bool {
 	s, substr = strings.ToLower(s), strings.ToLower(substr)
 	return strings.Contains(s, substr)
 }
+
+// filterByCommonArgs applies every SearchEventsArgs filter except Query and
+// Limit: location, date range and CFP constraints. It is shared by
+// search_events (which ranks Query matches separately) and by filterEvents,
+// which adds the Query and Limit filters on top for the iCal export and
+// calendar_links.
+func filterByCommonArgs(events []Event, args SearchEventsArgs) ([]Event, error) {
+	now := time.Now()
+
+	var fromDate, toDate, cfpFromDate, cfpToDate time.Time
+	var err error
+	if args.FromDate != "" {
+		if fromDate, err = time.Parse("2006-01-02", args.FromDate); err != nil {
+			return nil, fmt.Errorf("invalid fromDate format: %w", err)
+		}
+	}
+	if args.ToDate != "" {
+		if toDate, err = time.Parse("2006-01-02", args.ToDate); err != nil {
+			return nil, fmt.Errorf("invalid toDate format: %w", err)
+		}
+	}
+	if args.CFPFromDate != "" {
+		if cfpFromDate, err = time.Parse("2006-01-02", args.CFPFromDate); err != nil {
+			return nil, fmt.Errorf("invalid cfpFromDate format: %w", err)
+		}
+	}
+	if args.CFPToDate != "" {
+		if cfpToDate, err = time.Parse("2006-01-02", args.CFPToDate); err != nil {
+			return nil, fmt.Errorf("invalid cfpToDate format: %w", err)
+		}
+	}
+
+	var filtered []Event
+	for _, event := range events {
+		if args.Location != "" && !contains(event.Location, args.Location) {
+			continue
+		}
+		if !fromDate.IsZero() && event.StartDate.Before(fromDate) {
+			continue
+		}
+		if !toDate.IsZero() && event.StartDate.After(toDate) {
+			continue
+		}
+
+		if args.HasOpenCFP && (!event.CFPEndDate.After(now) || event.CFP.Link == "") {
+			continue
+		}
+		if !cfpFromDate.IsZero() && event.CFPEndDate.Before(cfpFromDate) {
+			continue
+		}
+		if !cfpToDate.IsZero() && event.CFPEndDate.After(cfpToDate) {
+			continue
+		}
+
+		filtered = append(filtered, event)
+	}
+
+	return filtered, nil
+}
+
+// filterEvents applies every SearchEventsArgs filter, including a plain
+// substring match on Query, plus Limit. It is the filter used by tools
+// that want a curated but unranked view of the feed, such as the iCal
+// export; search_events ranks Query matches instead of gating on them, see
+// scoreAndRankEvents.
+func filterEvents(events []Event, args SearchEventsArgs) ([]Event, error) {
+	filtered, err := filterByCommonArgs(events, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Query != "" {
+		var matched []Event
+		for _, event := range filtered {
+			if contains(event.Name+event.Location+event.City+event.Country+event.Misc, args.Query) {
+				matched = append(matched, event)
+			}
+		}
+		filtered = matched
+	}
+
+	if args.Limit > 0 && len(filtered) > args.Limit {
+		filtered = filtered[:args.Limit]
+	}
+
+	return filtered, nil
+}
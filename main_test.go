@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -77,9 +78,10 @@ func TestFetchAndParseEvents(t *testing.T) {
 
 		// Set test server URL for the test
 		eventDataURL = server.URL
+		resetEventCache()
 
 		// Test function
-		events, err := FetchAndParseEvents()
+		events, err := FetchAndParseEvents(context.Background())
 		if err != nil {
 			t.Fatalf("fetchAndParseEvents returned error: %v", err)
 		}
@@ -124,9 +126,10 @@ func TestFetchAndParseEvents(t *testing.T) {
 
 		// Set test server URL for the test
 		eventDataURL = server.URL
+		resetEventCache()
 
 		// Test function
-		events, err := FetchAndParseEvents()
+		events, err := FetchAndParseEvents(context.Background())
 		if err != nil {
 			t.Fatalf("fetchAndParseEvents returned error: %v", err)
 		}
@@ -141,6 +144,16 @@ func TestFetchAndParseEvents(t *testing.T) {
 	})
 
 	t.Run("Handles HTTP errors", func(t *testing.T) {
+		// A 500 response is retried by doWithRetry; use short backoffs so
+		// this test doesn't pay the real 250ms->1s->4s delays.
+		originalRetries, originalBackoffs := httpRetries, retryBackoffs
+		httpRetries = 1
+		retryBackoffs = []time.Duration{time.Millisecond}
+		defer func() {
+			httpRetries = originalRetries
+			retryBackoffs = originalBackoffs
+		}()
+
 		// Create test server with error response
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -149,9 +162,10 @@ func TestFetchAndParseEvents(t *testing.T) {
 
 		// Set test server URL for the test
 		eventDataURL = server.URL
+		resetEventCache()
 
 		// Test function
-		_, err := FetchAndParseEvents()
+		_, err := FetchAndParseEvents(context.Background())
 		if err == nil {
 			t.Fatal("Expected error for HTTP 500 status, got nil")
 		}
@@ -166,9 +180,10 @@ func TestFetchAndParseEvents(t *testing.T) {
 
 		// Set test server URL for the test
 		eventDataURL = server.URL
+		resetEventCache()
 
 		// Test function
-		_, err := FetchAndParseEvents()
+		_, err := FetchAndParseEvents(context.Background())
 		if err == nil {
 			t.Fatal("Expected error for malformed JSON, got nil")
 		}
@@ -196,7 +211,8 @@ func TestMillisToTime(t *testing.T) {
 
 func TestFetchRealEvents(t *testing.T) {
 	// This test fetches data from the real endpoint
-	events, err := FetchAndParseEvents()
+	resetEventCache()
+	events, err := FetchAndParseEvents(context.Background())
 	if err != nil {
 		t.Fatalf("Error fetching real events: %v", err)
 	}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxFieldWeight is the weight of the highest-weighted field (Name),
+// used to normalize per-field scores back into the 0-1 range.
+const maxFieldWeight = 3.0
+
+// ScoredEvent pairs an Event with how well it matched a search_events
+// query, so LLM callers can reason about relevance instead of treating
+// every result as an equally good match.
+type ScoredEvent struct {
+	Event
+	Score float64 `json:"score"`
+}
+
+// scoreAndRankEvents scores each event against args.Query (fields weighted
+// Name x3, Location/City/Country x2, Misc x1), drops anything below
+// args.MinScore, sorts by descending score and applies args.Limit. When
+// Query is empty every event scores 1, preserving the input order.
+func scoreAndRankEvents(events []Event, args SearchEventsArgs) []ScoredEvent {
+	scored := make([]ScoredEvent, 0, len(events))
+	for _, event := range events {
+		score := 1.0
+		if args.Query != "" {
+			score = scoreEvent(event, args.Query)
+			if score < args.MinScore {
+				continue
+			}
+		}
+		scored = append(scored, ScoredEvent{Event: event, Score: score})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool { return scored[i].Score > scored[j].Score })
+
+	if args.Limit > 0 && len(scored) > args.Limit {
+		scored = scored[:args.Limit]
+	}
+
+	return scored
+}
+
+// scoreEvent returns how well event matches query, in [0, 1]. The query is
+// tokenized on whitespace; each token is matched against every weighted
+// field and the best per-field score is kept, so a multi-token query like
+// "kubernetes berlin" rewards events that match each word somewhere.
+func scoreEvent(event Event, query string) float64 {
+	tokens := tokenizeQuery(query)
+	if len(tokens) == 0 {
+		return 0
+	}
+
+	fields := []struct {
+		text   string
+		weight float64
+	}{
+		{event.Name, 3},
+		{event.Location, 2},
+		{event.City, 2},
+		{event.Country, 2},
+		{event.Misc, 1},
+	}
+
+	var total float64
+	for _, tok := range tokens {
+		var best float64
+		for _, f := range fields {
+			if f.text == "" {
+				continue
+			}
+			if s := fieldTokenScore(tok, f.text) * (f.weight / maxFieldWeight); s > best {
+				best = s
+			}
+		}
+		total += best
+	}
+
+	return total / float64(len(tokens))
+}
+
+// fieldTokenScore scores a single normalized query token against a field's
+// text: an exact substring match scores 1, otherwise the best Levenshtein
+// similarity against any word in the field wins.
+func fieldTokenScore(tok, field string) float64 {
+	normField := normalizeForSearch(field)
+	if tok == "" || normField == "" {
+		return 0
+	}
+	if strings.Contains(normField, tok) {
+		return 1
+	}
+
+	var best float64
+	for _, word := range strings.Fields(normField) {
+		if s := normalizedSimilarity(tok, word); s > best {
+			best = s
+		}
+	}
+	return best
+}
+
+// tokenizeQuery splits a query into normalized, non-empty tokens.
+func tokenizeQuery(query string) []string {
+	fields := strings.Fields(normalizeForSearch(query))
+	tokens := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f != "" {
+			tokens = append(tokens, f)
+		}
+	}
+	return tokens
+}
+
+// normalizeForSearch lowercases s and strips diacritics (e.g. "Café" ->
+// "cafe") so accented names don't block an otherwise exact match.
+func normalizeForSearch(s string) string {
+	folded, _, err := transform.String(diacriticsFolder, strings.ToLower(s))
+	if err != nil {
+		return strings.ToLower(s)
+	}
+	return folded
+}
+
+// diacriticsFolder decomposes accented runes (NFD) and drops the
+// resulting combining marks.
+var diacriticsFolder = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// normalizedSimilarity returns the Levenshtein similarity of a and b as a
+// value in [0, 1], where 1 means identical.
+func normalizedSimilarity(a, b string) float64 {
+	if a == "" && b == "" {
+		return 1
+	}
+	maxLen := len(a)
+	if len(b) > maxLen {
+		maxLen = len(b)
+	}
+	if maxLen == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(a, b))/float64(maxLen)
+}
+
+// levenshtein computes the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			deletion := prev[j] + 1
+			insertion := curr[j-1] + 1
+			substitution := prev[j-1] + cost
+			curr[j] = min3(deletion, insertion, substitution)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
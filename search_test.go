@@ -0,0 +1,115 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"kitten", "sitting", 3},
+		{"kubcon", "kubecon", 1},
+		{"berlin", "berlin", 0},
+	}
+
+	for _, tc := range testCases {
+		result := levenshtein(tc.a, tc.b)
+		if result != tc.expected {
+			t.Errorf("levenshtein(%q, %q) = %d, expected %d", tc.a, tc.b, result, tc.expected)
+		}
+	}
+}
+
+func TestNormalizeForSearch(t *testing.T) {
+	testCases := []struct {
+		in       string
+		expected string
+	}{
+		{"KubeCon", "kubecon"},
+		{"São Paulo", "sao paulo"},
+		{"Café Berlin", "cafe berlin"},
+	}
+
+	for _, tc := range testCases {
+		result := normalizeForSearch(tc.in)
+		if result != tc.expected {
+			t.Errorf("normalizeForSearch(%q) = %q, expected %q", tc.in, result, tc.expected)
+		}
+	}
+}
+
+func TestScoreEventTypoTolerance(t *testing.T) {
+	event := Event{Name: "KubeCon Europe", City: "Berlin", Country: "Germany"}
+
+	typoScore := scoreEvent(event, "kubcon")
+	if typoScore <= 0 {
+		t.Fatalf("expected a close typo to score above 0, got %v", typoScore)
+	}
+
+	noMatchScore := scoreEvent(event, "zzzzzzzzzz")
+	if noMatchScore >= typoScore {
+		t.Errorf("expected an unrelated query to score lower than a close typo, got %v vs %v", noMatchScore, typoScore)
+	}
+}
+
+func TestScoreEventDiacritics(t *testing.T) {
+	event := Event{Name: "São Paulo Dev Summit", City: "São Paulo", Country: "Brazil"}
+
+	score := scoreEvent(event, "sao paulo")
+	if score < 0.9 {
+		t.Errorf("expected diacritic-folded query to match closely, got %v", score)
+	}
+}
+
+func TestScoreEventMultiToken(t *testing.T) {
+	match := Event{Name: "Kubernetes Day", City: "Berlin", Country: "Germany"}
+	noMatch := Event{Name: "Kubernetes Day", City: "Madrid", Country: "Spain"}
+
+	matchScore := scoreEvent(match, "kubernetes berlin")
+	noMatchScore := scoreEvent(noMatch, "kubernetes berlin")
+
+	if matchScore <= noMatchScore {
+		t.Errorf("expected event matching both tokens to outscore one matching only 'kubernetes': %v vs %v", matchScore, noMatchScore)
+	}
+}
+
+func TestScoreAndRankEventsOrdersByScoreAndAppliesMinScore(t *testing.T) {
+	events := []Event{
+		{Name: "KubeCon Europe"},
+		{Name: "DevOps Days"},
+		{Name: "Kubcon"},
+	}
+
+	args := SearchEventsArgs{Query: "kubecon", MinScore: 0.5}
+	scored := scoreAndRankEvents(events, args)
+
+	if len(scored) != 2 {
+		t.Fatalf("expected 2 events above MinScore, got %d: %+v", len(scored), scored)
+	}
+	if scored[0].Score < scored[1].Score {
+		t.Errorf("expected results sorted by descending score, got %v then %v", scored[0].Score, scored[1].Score)
+	}
+	for _, s := range scored {
+		if s.Name == "DevOps Days" {
+			t.Errorf("expected unrelated event to be filtered out by MinScore, got %+v", s)
+		}
+	}
+}
+
+func TestScoreAndRankEventsNoQueryKeepsOrderAndScoresOne(t *testing.T) {
+	events := []Event{{Name: "A"}, {Name: "B"}}
+	scored := scoreAndRankEvents(events, SearchEventsArgs{})
+
+	if len(scored) != 2 {
+		t.Fatalf("expected both events to be kept, got %d", len(scored))
+	}
+	if scored[0].Name != "A" || scored[1].Name != "B" {
+		t.Errorf("expected original order to be preserved without a query, got %+v", scored)
+	}
+	if scored[0].Score != 1 || scored[1].Score != 1 {
+		t.Errorf("expected every result to score 1 without a query, got %+v", scored)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+	mcphttp "github.com/metoro-io/mcp-golang/transport/http"
+	"github.com/metoro-io/mcp-golang/transport/stdio"
+)
+
+// defaultHTTPAddr is the bind address used by the http transport when
+// neither --addr nor MCP_ADDR is set.
+const defaultHTTPAddr = ":8080"
+
+// transportConfig selects which MCP transport main should serve on.
+type transportConfig struct {
+	kind string
+	addr string
+}
+
+// parseTransportConfig parses --transport/--addr from args (os.Args[1:] in
+// production), falling back to the MCP_TRANSPORT/MCP_ADDR environment
+// variables and then to the stdio transport this server has always used.
+func parseTransportConfig(args []string) (transportConfig, error) {
+	fs := flag.NewFlagSet("developers-conferences-agenda-mcp", flag.ContinueOnError)
+	kind := fs.String("transport", envOr("MCP_TRANSPORT", "stdio"), `MCP transport to serve: "stdio" or "http"`)
+	addr := fs.String("addr", envOr("MCP_ADDR", defaultHTTPAddr), "address to bind the http transport to")
+	if err := fs.Parse(args); err != nil {
+		return transportConfig{}, err
+	}
+
+	cfg := transportConfig{kind: *kind, addr: *addr}
+	if cfg.kind != "stdio" && cfg.kind != "http" {
+		return transportConfig{}, fmt.Errorf("unknown transport %q: must be \"stdio\" or \"http\"", cfg.kind)
+	}
+	return cfg, nil
+}
+
+// envOr returns the environment variable key, or fallback if it is unset or
+// empty.
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// mcpEndpoint is the path the http transport serves the MCP protocol on.
+const mcpEndpoint = "/mcp"
+
+// shutdowner is implemented by transports that own a listener and so need to
+// be drained on SIGINT/SIGTERM; stdio has no listener and is served without
+// one. This matches transport/http.HTTPTransport, which only exposes
+// Close() error (no context), so waitForShutdown applies its own timeout
+// around the call instead of passing one through.
+type shutdowner interface {
+	Close() error
+}
+
+// newTransportServer builds the mcp_golang.Server for cfg, returning the
+// shutdowner to drain on exit alongside it (nil for the stdio transport).
+func newTransportServer(cfg transportConfig) (*mcp_golang.Server, shutdowner) {
+	if cfg.kind == "http" {
+		t := mcphttp.NewHTTPTransport(mcpEndpoint).WithAddr(cfg.addr)
+		return mcp_golang.NewServer(t), t
+	}
+	return mcp_golang.NewServer(stdio.NewStdioServerTransport()), nil
+}
+
+// osExit is os.Exit, overridable in tests so the stdio shutdown path below
+// can be exercised without killing the test binary.
+var osExit = os.Exit
+
+// waitForShutdown blocks until either serveErr reports the transport server
+// exiting on its own or ctx is canceled by SIGINT/SIGTERM. The stdio
+// transport has no listener to drain and Serve() blocks reading stdin with
+// no way to cancel it, so on signal it exits immediately, matching the OS
+// default terminate-on-signal action we're overriding by listening for it;
+// every other transport is drained via shutdown before serveErr is awaited.
+func waitForShutdown(ctx context.Context, cfg transportConfig, shutdown shutdowner, serveErr <-chan error) error {
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+		if shutdown == nil {
+			osExit(0)
+			return nil
+		}
+
+		closeErr := make(chan error, 1)
+		go func() { closeErr <- shutdown.Close() }()
+
+		select {
+		case err := <-closeErr:
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error shutting down %s transport: %v\n", cfg.kind, err)
+			}
+		case <-time.After(10 * time.Second):
+			fmt.Fprintf(os.Stderr, "timed out shutting down %s transport\n", cfg.kind)
+		}
+		return <-serveErr
+	}
+}
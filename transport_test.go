@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+	mcphttp "github.com/metoro-io/mcp-golang/transport/http"
+)
+
+func TestParseTransportConfigDefaultsToStdio(t *testing.T) {
+	cfg, err := parseTransportConfig(nil)
+	if err != nil {
+		t.Fatalf("parseTransportConfig returned error: %v", err)
+	}
+	if cfg.kind != "stdio" {
+		t.Errorf("expected default transport %q, got %q", "stdio", cfg.kind)
+	}
+	if cfg.addr != defaultHTTPAddr {
+		t.Errorf("expected default addr %q, got %q", defaultHTTPAddr, cfg.addr)
+	}
+}
+
+func TestParseTransportConfigFlags(t *testing.T) {
+	cfg, err := parseTransportConfig([]string{"--transport", "http", "--addr", ":9090"})
+	if err != nil {
+		t.Fatalf("parseTransportConfig returned error: %v", err)
+	}
+	if cfg.kind != "http" {
+		t.Errorf("expected transport %q, got %q", "http", cfg.kind)
+	}
+	if cfg.addr != ":9090" {
+		t.Errorf("expected addr %q, got %q", ":9090", cfg.addr)
+	}
+}
+
+func TestParseTransportConfigRejectsUnknownTransport(t *testing.T) {
+	if _, err := parseTransportConfig([]string{"--transport", "websocket"}); err == nil {
+		t.Fatal("expected an error for an unknown transport, got nil")
+	}
+}
+
+// TestSearchEventsOverHTTPTransport spins up the http transport over an
+// httptest server and exercises search_events through it end to end.
+func TestSearchEventsOverHTTPTransport(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events := []map[string]interface{}{
+			{
+				"name":      "TransportConf 2025",
+				"hyperlink": "https://transportconf.example.com",
+				"date":      []int64{1762752000000},
+				"location":  "Remote",
+				"city":      "Remote",
+				"country":   "Online",
+				"cfp":       map[string]interface{}{},
+				"status":    "open",
+			},
+		}
+		json.NewEncoder(w).Encode(events)
+	}))
+	defer upstream.Close()
+
+	originalURL := eventDataURL
+	eventDataURL = upstream.URL
+	resetEventCache()
+	defer func() { eventDataURL = originalURL }()
+
+	transport := mcphttp.NewHTTPTransport(mcpEndpoint).WithAddr(":0")
+	server := mcp_golang.NewServer(transport)
+	if err := registerTools(server); err != nil {
+		t.Fatalf("registerTools returned error: %v", err)
+	}
+
+	mcpServer := httptest.NewServer(transport)
+	defer mcpServer.Close()
+
+	reqBody, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "tools/call",
+		"params": map[string]interface{}{
+			"name":      "search_events",
+			"arguments": map[string]interface{}{"query": "TransportConf"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	resp, err := http.Post(mcpServer.URL+mcpEndpoint, "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		t.Fatalf("failed to call search_events over http transport: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+// TestWaitForShutdownExitsOnSignalForStdio guards against the stdio
+// transport hanging forever on SIGINT/SIGTERM: Serve() never returns on its
+// own for stdio, so waitForShutdown must exit the process itself rather
+// than block on serveErr.
+func TestWaitForShutdownExitsOnSignalForStdio(t *testing.T) {
+	originalExit := osExit
+	exitCode := make(chan int, 1)
+	osExit = func(code int) { exitCode <- code }
+	defer func() { osExit = originalExit }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	serveErr := make(chan error, 1)
+	done := make(chan error, 1)
+	go func() { done <- waitForShutdown(ctx, transportConfig{kind: "stdio"}, nil, serveErr) }()
+
+	select {
+	case code := <-exitCode:
+		if code != 0 {
+			t.Errorf("expected exit code 0, got %d", code)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waitForShutdown did not exit for the stdio transport on signal")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("waitForShutdown did not return after exiting")
+	}
+}
+
+// fakeShutdowner records whether Close was called, standing in for the http
+// transport's real shutdowner.
+type fakeShutdowner struct {
+	called chan struct{}
+}
+
+func (f *fakeShutdowner) Close() error {
+	close(f.called)
+	return nil
+}
+
+func TestWaitForShutdownDrainsHTTPTransportOnSignal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fake := &fakeShutdowner{called: make(chan struct{})}
+	serveErr := make(chan error, 1)
+
+	done := make(chan error, 1)
+	go func() { done <- waitForShutdown(ctx, transportConfig{kind: "http"}, fake, serveErr) }()
+
+	select {
+	case <-fake.called:
+	case <-time.After(time.Second):
+		t.Fatal("expected Close to be called for the http transport")
+	}
+
+	serveErr <- nil
+	if err := <-done; err != nil {
+		t.Errorf("waitForShutdown returned error: %v", err)
+	}
+}